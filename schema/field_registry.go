@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON lets a Field be loaded from a JSON/YAML schema definition
+// such as {"type":"string","pattern":"^[a-z]+$","enum":["a","b"]}. The
+// "type" key is resolved through the RegisterValidator registry; any other
+// recognized key ("pattern", "enum", "base", "baseParams", ...) is passed
+// through to the matching ValidatorFactory as-is.
+func (f *Field) UnmarshalJSON(data []byte) error {
+	var def struct {
+		Type       string      `json:"type"`
+		Required   bool        `json:"required"`
+		ReadOnly   bool        `json:"readOnly"`
+		Hidden     bool        `json:"hidden"`
+		Filterable bool        `json:"filterable"`
+		Sortable   bool        `json:"sortable"`
+		Pattern    string      `json:"pattern"`
+		Enum       interface{} `json:"enum"`
+		Base       string      `json:"base"`
+		BaseParams interface{} `json:"baseParams"`
+	}
+	if err := json.Unmarshal(data, &def); err != nil {
+		return err
+	}
+	*f = Field{
+		Required:   def.Required,
+		ReadOnly:   def.ReadOnly,
+		Hidden:     def.Hidden,
+		Filterable: def.Filterable,
+		Sortable:   def.Sortable,
+	}
+	if def.Type == "" {
+		return nil
+	}
+	params := map[string]interface{}{}
+	if def.Pattern != "" {
+		params["pattern"] = def.Pattern
+	}
+	if def.Enum != nil {
+		params["enum"] = def.Enum
+	}
+	if def.Base != "" {
+		params["base"] = def.Base
+	}
+	if def.BaseParams != nil {
+		params["baseParams"] = def.BaseParams
+	}
+	validator, err := newRegisteredValidator(def.Type, params)
+	if err != nil {
+		return fmt.Errorf("field: %s", err)
+	}
+	f.Validator = validator
+	return nil
+}
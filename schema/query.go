@@ -5,8 +5,44 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
+	"time"
 )
 
+// resolveTypedLiteral turns a "DATE yyyy-mm-dd" or "TIME rfc3339" typed
+// literal into a time.Time, so the JSON form of a query can disambiguate a
+// date from an ordinary string without needing the field's validator. Values
+// that don't match either prefix are returned unchanged.
+func resolveTypedLiteral(exp interface{}) interface{} {
+	str, ok := exp.(string)
+	if !ok {
+		return exp
+	}
+	switch {
+	case strings.HasPrefix(str, "DATE "):
+		if t, err := time.Parse("2006-01-02", strings.TrimSpace(str[len("DATE "):])); err == nil {
+			return t
+		}
+	case strings.HasPrefix(str, "TIME "):
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(str[len("TIME "):])); err == nil {
+			return t
+		}
+	}
+	return exp
+}
+
+// isStringValidator reports whether v is a String validator, accepting both
+// the value and the pointer form since fields are commonly declared with a
+// pointer validator (e.g. &String{}).
+func isStringValidator(v FieldValidator) bool {
+	switch v.(type) {
+	case String, *String:
+		return true
+	}
+	return false
+}
+
 // Query defines an expression against a schema to perform a match schema's data
 type Query map[string]interface{}
 
@@ -54,20 +90,39 @@ func validateQuery(q map[string]interface{}, validator Validator, parentKey stri
 			if parentKey == "" {
 				return fmt.Errorf("%s can't be at first level", op)
 			}
-			if _, ok := isNumber(exp); !ok {
-				return fmt.Errorf("%s: value for %s must be a number", parentKey, op)
-			}
+			// Resolve a "DATE yyyy-mm-dd" / "TIME rfc3339" typed literal into
+			// a time.Time so it doesn't need the field's validator to be
+			// disambiguated from an ordinary string, and cache the result
+			// like $regex caches its compiled pattern.
+			exp = resolveTypedLiteral(exp)
+			q[key] = exp
 			if field := validator.GetField(parentKey); field != nil {
 				if field.Validator != nil {
 					switch field.Validator.(type) {
-					case Integer, Float:
+					case Integer, *Integer, Float, *Float:
+						if _, ok := isNumber(exp); !ok {
+							return fmt.Errorf("%s: value for %s must be a number", parentKey, op)
+						}
 						if _, err := field.Validator.Validate(exp); err != nil {
 							return fmt.Errorf("invalid query expression for field `%s': %s", parentKey, err)
 						}
+					case Time, *Time:
+						v, err := field.Validator.Validate(exp)
+						if err != nil {
+							return fmt.Errorf("invalid query expression for field `%s': %s", parentKey, err)
+						}
+						// Store the coerced time.Time back, so a plain
+						// RFC3339 string also ends up in the shape matchQuery
+						// expects, just like the DATE/TIME literal case above.
+						q[key] = v
 					default:
 						return fmt.Errorf("%s: cannot apply %s operation on a non numerical field", parentKey, op)
 					}
 				}
+			} else if _, ok := isNumber(exp); !ok {
+				if _, ok := exp.(time.Time); !ok {
+					return fmt.Errorf("%s: value for %s must be a number", parentKey, op)
+				}
 			}
 		case "$in", "$nin":
 			op := key
@@ -90,6 +145,52 @@ func validateQuery(q map[string]interface{}, validator Validator, parentKey stri
 					}
 				}
 			}
+		case "$regex":
+			op := key
+			if parentKey == "" {
+				return fmt.Errorf("%s can't be at first level", op)
+			}
+			pattern, ok := exp.(string)
+			if !ok {
+				return fmt.Errorf("%s: value for %s must be a string", parentKey, op)
+			}
+			if field := validator.GetField(parentKey); field != nil {
+				if field.Validator != nil {
+					if !isStringValidator(field.Validator) {
+						return fmt.Errorf("%s: cannot apply %s operation on a non string field", parentKey, op)
+					}
+				}
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%s: invalid pattern for %s: %s", parentKey, op, err)
+			}
+			q[key] = re
+		case "$exists":
+			op := key
+			if parentKey == "" {
+				return fmt.Errorf("%s can't be at first level", op)
+			}
+			if _, ok := exp.(bool); !ok {
+				return fmt.Errorf("%s: value for %s must be a boolean", parentKey, op)
+			}
+		case "$contains":
+			op := key
+			if parentKey == "" {
+				return fmt.Errorf("%s can't be at first level", op)
+			}
+			if field := validator.GetField(parentKey); field != nil {
+				if isStringValidator(field.Validator) {
+					// $contains on a String field is a substring match, so exp
+					// is directly comparable to what the field itself accepts.
+					if _, err := field.Validator.Validate(exp); err != nil {
+						return fmt.Errorf("invalid query expression for field `%s': %s", parentKey, err)
+					}
+				}
+				// For a slice/array field, exp is a single element to look
+				// up, not a whole value for the field's validator to accept,
+				// so there's nothing generic to validate it against here.
+			}
 		case "$or", "$and":
 			op := key
 			var subQueries []interface{}
@@ -156,24 +257,52 @@ func matchQuery(q Query, payload map[string]interface{}, parentKey string) bool
 				return false
 			}
 		case "$gt":
+			if t1, ok := exp.(time.Time); ok {
+				t2, ok2 := getField(payload, parentKey).(time.Time)
+				if !(ok2 && t2.After(t1)) {
+					return false
+				}
+				break
+			}
 			n1, ok1 := isNumber(exp)
 			n2, ok2 := isNumber(getField(payload, parentKey))
 			if !(ok1 && ok2 && (n1 < n2)) {
 				return false
 			}
 		case "$gte":
+			if t1, ok := exp.(time.Time); ok {
+				t2, ok2 := getField(payload, parentKey).(time.Time)
+				if !(ok2 && (t2.After(t1) || t2.Equal(t1))) {
+					return false
+				}
+				break
+			}
 			n1, ok1 := isNumber(exp)
 			n2, ok2 := isNumber(getField(payload, parentKey))
 			if !(ok1 && ok2 && (n1 <= n2)) {
 				return false
 			}
 		case "$lt":
+			if t1, ok := exp.(time.Time); ok {
+				t2, ok2 := getField(payload, parentKey).(time.Time)
+				if !(ok2 && t2.Before(t1)) {
+					return false
+				}
+				break
+			}
 			n1, ok1 := isNumber(exp)
 			n2, ok2 := isNumber(getField(payload, parentKey))
 			if !(ok1 && ok2 && (n1 > n2)) {
 				return false
 			}
 		case "$lte":
+			if t1, ok := exp.(time.Time); ok {
+				t2, ok2 := getField(payload, parentKey).(time.Time)
+				if !(ok2 && (t2.Before(t1) || t2.Equal(t1))) {
+					return false
+				}
+				break
+			}
 			n1, ok1 := isNumber(exp)
 			n2, ok2 := isNumber(getField(payload, parentKey))
 			if !(ok1 && ok2 && (n1 >= n2)) {
@@ -187,6 +316,38 @@ func matchQuery(q Query, payload map[string]interface{}, parentKey string) bool
 			if isIn(exp, getField(payload, parentKey)) {
 				return false
 			}
+		case "$regex":
+			re, ok := exp.(*regexp.Regexp)
+			str, sok := getField(payload, parentKey).(string)
+			if !ok || !sok || !re.MatchString(str) {
+				return false
+			}
+		case "$exists":
+			want, _ := exp.(bool)
+			if (getField(payload, parentKey) != nil) != want {
+				return false
+			}
+		case "$contains":
+			switch val := getField(payload, parentKey).(type) {
+			case string:
+				sub, ok := exp.(string)
+				if !ok || !strings.Contains(val, sub) {
+					return false
+				}
+			case []interface{}:
+				found := false
+				for _, item := range val {
+					if reflect.DeepEqual(item, exp) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			default:
+				return false
+			}
 		case "$or":
 			pass := false
 			if subQueries, ok := exp.([]Query); ok {
@@ -222,4 +383,91 @@ func matchQuery(q Query, payload map[string]interface{}, parentKey string) bool
 		}
 	}
 	return true
+}
+
+// QueryTranslator turns a validated Query into a backend-native filter.
+// Storage adapters implement it instead of re-walking the untyped query map
+// and re-detecting operator keys themselves: Query.Translate handles the
+// traversal, including nested $and/$or, and calls back into the translator
+// only for the per-operator emission.
+//
+// op is one of "$eq" (exact match), "$ne", "$gt", "$gte", "$lt", "$lte",
+// "$in", "$nin", "$regex", "$exists" or "$contains". value has already been
+// through the same resolution Match uses (e.g. a compiled *regexp.Regexp for
+// "$regex", a time.Time for a DATE/TIME literal).
+type QueryTranslator interface {
+	// Field emits the backend-native fragment for a single field operator.
+	Field(name string, op string, value interface{}) (interface{}, error)
+	// And combines multiple fragments with a logical AND.
+	And(parts []interface{}) interface{}
+	// Or combines multiple fragments with a logical OR.
+	Or(parts []interface{}) interface{}
+}
+
+// Translate walks the query and asks t to emit a backend-native filter for
+// it. Translate assumes q has already been validated (e.g. by ParseQuery),
+// just like Match does.
+func (q Query) Translate(t QueryTranslator) (interface{}, error) {
+	parts, err := translateQuery(q, t)
+	if err != nil {
+		return nil, err
+	}
+	switch len(parts) {
+	case 0:
+		return nil, nil
+	case 1:
+		return parts[0], nil
+	default:
+		return t.And(parts), nil
+	}
+}
+
+func translateQuery(q Query, t QueryTranslator) ([]interface{}, error) {
+	var parts []interface{}
+	for key, exp := range q {
+		switch key {
+		case "$and", "$or":
+			subQueries, _ := exp.([]Query)
+			sub := make([]interface{}, 0, len(subQueries))
+			for _, subQuery := range subQueries {
+				v, err := subQuery.Translate(t)
+				if err != nil {
+					return nil, err
+				}
+				sub = append(sub, v)
+			}
+			if key == "$and" {
+				parts = append(parts, t.And(sub))
+			} else {
+				parts = append(parts, t.Or(sub))
+			}
+		default:
+			if subQuery, ok := exp.(Query); ok {
+				fieldParts, err := translateFieldQuery(key, subQuery, t)
+				if err != nil {
+					return nil, err
+				}
+				parts = append(parts, fieldParts...)
+			} else {
+				v, err := t.Field(key, "$eq", exp)
+				if err != nil {
+					return nil, err
+				}
+				parts = append(parts, v)
+			}
+		}
+	}
+	return parts, nil
+}
+
+func translateFieldQuery(name string, sub Query, t QueryTranslator) ([]interface{}, error) {
+	parts := make([]interface{}, 0, len(sub))
+	for op, exp := range sub {
+		v, err := t.Field(name, op, exp)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, v)
+	}
+	return parts, nil
 }
\ No newline at end of file
@@ -0,0 +1,9 @@
+// Package query provides reference implementations of schema.QueryTranslator.
+//
+// MongoTranslator emits MongoDB-style filter documents, suitable as a
+// starting point for a storage adapter backed by mgo or the official
+// mongo-driver. MatchTranslator rebuilds the same map[string]interface{}
+// shape schema.Query.Match consumes, so translating a query through it and
+// running Match against the result is equivalent to calling Match directly;
+// it mostly exists as a cheap baseline to validate new translators against.
+package query
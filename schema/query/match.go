@@ -0,0 +1,40 @@
+package query
+
+import "github.com/rs/rest-layer/schema"
+
+// MatchTranslator rebuilds the map[string]interface{} representation
+// consumed by schema.Query.Match: translating a query through it and
+// wrapping the result with schema.Query(...) is equivalent to using the
+// original query directly.
+type MatchTranslator struct{}
+
+// Field implements schema.QueryTranslator.
+func (MatchTranslator) Field(name, op string, value interface{}) (interface{}, error) {
+	if op == "$eq" {
+		return map[string]interface{}{name: value}, nil
+	}
+	return map[string]interface{}{name: schema.Query{op: value}}, nil
+}
+
+// And implements schema.QueryTranslator.
+func (MatchTranslator) And(parts []interface{}) interface{} {
+	return map[string]interface{}{"$and": toQueries(parts)}
+}
+
+// Or implements schema.QueryTranslator.
+func (MatchTranslator) Or(parts []interface{}) interface{} {
+	return map[string]interface{}{"$or": toQueries(parts)}
+}
+
+// toQueries converts the []interface{} produced by Translate's traversal
+// into the []schema.Query matchQuery's $and/$or branches require; each part
+// is itself a map[string]interface{} built by Field, And or Or.
+func toQueries(parts []interface{}) []schema.Query {
+	qs := make([]schema.Query, len(parts))
+	for i, part := range parts {
+		if m, ok := part.(map[string]interface{}); ok {
+			qs[i] = schema.Query(m)
+		}
+	}
+	return qs
+}
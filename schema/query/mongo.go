@@ -0,0 +1,62 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// mongoOps maps a schema.Query operator to its MongoDB equivalent. "$eq" and
+// "$contains" have no entry as they're handled directly by
+// MongoTranslator.Field.
+var mongoOps = map[string]string{
+	"$ne":     "$ne",
+	"$gt":     "$gt",
+	"$gte":    "$gte",
+	"$lt":     "$lt",
+	"$lte":    "$lte",
+	"$in":     "$in",
+	"$nin":    "$nin",
+	"$regex":  "$regex",
+	"$exists": "$exists",
+}
+
+// MongoTranslator translates a validated schema.Query into a MongoDB filter
+// document. The result is a map[string]interface{}, which both mgo's bson.M
+// and the official mongo-driver's bson.M accept interchangeably.
+type MongoTranslator struct{}
+
+// Field implements schema.QueryTranslator.
+func (MongoTranslator) Field(name, op string, value interface{}) (interface{}, error) {
+	if op == "$eq" {
+		return map[string]interface{}{name: value}, nil
+	}
+	if op == "$contains" {
+		// The field's type is not known here, so the choice is made on the
+		// value: a []interface{} value is emitted as $in (the field holds any
+		// of the values), and any other value as an unanchored, escaped
+		// $regex (a substring match), including a single element looked up
+		// in an array field.
+		if values, ok := value.([]interface{}); ok {
+			return map[string]interface{}{name: map[string]interface{}{"$in": values}}, nil
+		}
+		return map[string]interface{}{name: map[string]interface{}{"$regex": regexp.QuoteMeta(fmt.Sprint(value))}}, nil
+	}
+	mop, ok := mongoOps[op]
+	if !ok {
+		return nil, fmt.Errorf("mongo translator: unsupported operator %s", op)
+	}
+	if re, ok := value.(*regexp.Regexp); ok {
+		value = re.String()
+	}
+	return map[string]interface{}{name: map[string]interface{}{mop: value}}, nil
+}
+
+// And implements schema.QueryTranslator.
+func (MongoTranslator) And(parts []interface{}) interface{} {
+	return map[string]interface{}{"$and": parts}
+}
+
+// Or implements schema.QueryTranslator.
+func (MongoTranslator) Or(parts []interface{}) interface{} {
+	return map[string]interface{}{"$or": parts}
+}
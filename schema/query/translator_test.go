@@ -0,0 +1,114 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/rs/rest-layer/schema"
+	"github.com/rs/rest-layer/schema/query"
+)
+
+func sampleQuery() schema.Query {
+	return schema.Query{
+		"status": "active",
+		"age":    schema.Query{"$gt": 7.0},
+	}
+}
+
+func TestMongoTranslator(t *testing.T) {
+	q := sampleQuery()
+	got, err := q.Translate(query.MongoTranslator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	and, ok := got.(map[string]interface{})["$and"].([]interface{})
+	if !ok || len(and) != 2 {
+		t.Fatalf("expected a 2-part $and, got %v", got)
+	}
+}
+
+func TestMatchTranslatorRoundTrip(t *testing.T) {
+	q := sampleQuery()
+	payload := map[string]interface{}{"status": "active", "age": 12.0}
+
+	translated, err := q.Translate(query.MatchTranslator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rebuilt, ok := translated.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a map, got %T", translated)
+	}
+
+	if got, want := q.Match(payload), schema.Query(rebuilt).Match(payload); got != want {
+		t.Errorf("round-tripped query matched %v, original matched %v", got, want)
+	}
+	if !schema.Query(rebuilt).Match(payload) {
+		t.Error("expected the round-tripped query to match the payload")
+	}
+}
+
+func TestMatchTranslatorRoundTripNoMatch(t *testing.T) {
+	q := sampleQuery()
+	payload := map[string]interface{}{"status": "inactive", "age": 12.0}
+
+	translated, err := q.Translate(query.MatchTranslator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rebuilt := translated.(map[string]interface{})
+
+	if got, want := q.Match(payload), schema.Query(rebuilt).Match(payload); got != want {
+		t.Errorf("round-tripped query matched %v, original matched %v", got, want)
+	}
+}
+
+func TestQueryTranslateEmpty(t *testing.T) {
+	got, err := schema.Query{}.Translate(query.MongoTranslator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != nil {
+		t.Errorf("expected an empty query to translate to nil, got %v", got)
+	}
+}
+
+func TestMongoTranslatorUnsupportedOperator(t *testing.T) {
+	q := schema.Query{"age": schema.Query{"$bogus": 1}}
+	if _, err := q.Translate(query.MongoTranslator{}); err == nil {
+		t.Error("expected an error for an unsupported operator")
+	}
+}
+
+func TestMongoTranslatorContainsOnStringFieldIsRegex(t *testing.T) {
+	q := schema.Query{"name": schema.Query{"$contains": "foo.bar"}}
+	got, err := q.Translate(query.MongoTranslator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	name, ok := got.(map[string]interface{})["name"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a map for \"name\", got %v", got)
+	}
+	re, ok := name["$regex"].(string)
+	if !ok {
+		t.Fatalf("expected $contains on a string field to translate to $regex, got %v", name)
+	}
+	if re != `foo\.bar` {
+		t.Errorf("expected the pattern to be escaped, got %q", re)
+	}
+}
+
+func TestMongoTranslatorContainsOnSliceFieldIsIn(t *testing.T) {
+	q := schema.Query{"tags": schema.Query{"$contains": []interface{}{"a", "b"}}}
+	got, err := q.Translate(query.MongoTranslator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tags, ok := got.(map[string]interface{})["tags"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a map for \"tags\", got %v", got)
+	}
+	if _, ok := tags["$in"]; !ok {
+		t.Errorf("expected $contains on a slice value to translate to $in, got %v", tags)
+	}
+}
@@ -0,0 +1,436 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseQueryString parses and validates a query expressed using the
+// human-friendly string DSL (e.g. `tx.gas > 7 AND status = "active" AND tags
+// IN ("a", "b")`) instead of the JSON/Mongo-style object accepted by
+// ParseQuery.
+//
+// The DSL is compiled by a hand-written lexer and recursive-descent parser
+// straight into the same map[string]interface{} shape produced by decoding a
+// JSON query, so the result is run through the very same validateQuery used
+// by ParseQuery and Match keeps working unmodified.
+//
+// TODO: this request asked for an alternative `?filter=` mode on the REST
+// handler, so clients can send this instead of URL-encoded JSON. That half
+// is not done here: the handler package isn't part of this tree, so there is
+// nowhere to wire it up. Call ParseQueryString directly wherever a request's
+// raw filter string needs parsing until that integration lands.
+func ParseQueryString(expr string, validator Validator) (Query, error) {
+	if strings.TrimSpace(expr) == "" {
+		return Query{}, nil
+	}
+	tokens, err := lexQueryString(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryStringParser{tokens: tokens}
+	q, err := p.parseOrExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != qsTokEOF {
+		return nil, fmt.Errorf("at column %d: unexpected %s", tok.col, tok.text)
+	}
+	if err := validateQuery(q, validator, ""); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// qsTokKind identifies the kind of a token produced by the query string
+// lexer.
+type qsTokKind int
+
+const (
+	qsTokEOF qsTokKind = iota
+	qsTokIdent
+	qsTokOp
+	qsTokLParen
+	qsTokRParen
+	qsTokComma
+	qsTokString
+	qsTokNumber
+	qsTokDate
+	qsTokTime
+	qsTokAnd
+	qsTokOr
+	qsTokNot
+	qsTokIn
+	qsTokContains
+	qsTokBool
+	qsTokNull
+)
+
+type qsToken struct {
+	kind qsTokKind
+	text string
+	col  int // 1-based column of the first rune of the token
+}
+
+// lexQueryString scans expr into a flat list of tokens terminated by an EOF
+// token. Errors report the 1-based column at which the lexer got stuck.
+func lexQueryString(expr string) ([]qsToken, error) {
+	var tokens []qsToken
+	runes := []rune(expr)
+	i := 0
+	col := func(pos int) int { return pos + 1 }
+	for i < len(runes) {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, qsToken{qsTokLParen, "(", col(i)})
+			i++
+		case c == ')':
+			tokens = append(tokens, qsToken{qsTokRParen, ")", col(i)})
+			i++
+		case c == ',':
+			tokens = append(tokens, qsToken{qsTokComma, ",", col(i)})
+			i++
+		case c == '"' || c == '\'':
+			start := i
+			quote := c
+			i++
+			var sb strings.Builder
+			closed := false
+			for i < len(runes) {
+				if runes[i] == quote {
+					i++
+					closed = true
+					break
+				}
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("at column %d: unterminated string literal", col(start))
+			}
+			tokens = append(tokens, qsToken{qsTokString, sb.String(), col(start)})
+		case c == '=':
+			tokens = append(tokens, qsToken{qsTokOp, "=", col(i)})
+			i++
+		case c == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, qsToken{qsTokOp, "!=", col(i)})
+				i += 2
+			} else {
+				return nil, fmt.Errorf("at column %d: expected '!=', got '!'", col(i))
+			}
+		case c == '>' || c == '<':
+			op := string(c)
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				op += "="
+				i++
+			}
+			tokens = append(tokens, qsToken{qsTokOp, op, col(start)})
+		case c >= '0' && c <= '9' || (c == '-' && i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9'):
+			start := i
+			i++
+			for i < len(runes) && (runes[i] >= '0' && runes[i] <= '9' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, qsToken{qsTokNumber, string(runes[start:i]), col(start)})
+		case isIdentRune(c, true):
+			start := i
+			i++
+			for i < len(runes) && isIdentRune(runes[i], false) {
+				i++
+			}
+			word := string(runes[start:i])
+			switch strings.ToUpper(word) {
+			case "AND":
+				tokens = append(tokens, qsToken{qsTokAnd, word, col(start)})
+			case "OR":
+				tokens = append(tokens, qsToken{qsTokOr, word, col(start)})
+			case "NOT":
+				tokens = append(tokens, qsToken{qsTokNot, word, col(start)})
+			case "IN":
+				tokens = append(tokens, qsToken{qsTokIn, word, col(start)})
+			case "CONTAINS":
+				tokens = append(tokens, qsToken{qsTokContains, word, col(start)})
+			case "TRUE", "FALSE":
+				tokens = append(tokens, qsToken{qsTokBool, word, col(start)})
+			case "NULL":
+				tokens = append(tokens, qsToken{qsTokNull, word, col(start)})
+			case "DATE", "TIME":
+				// The literal's payload is the following quoted or bare token.
+				lit, litCol, n, err := lexTypedLiteral(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				kind := qsTokDate
+				if strings.ToUpper(word) == "TIME" {
+					kind = qsTokTime
+				}
+				tokens = append(tokens, qsToken{kind, lit, litCol})
+				i = n
+				_ = start
+			default:
+				tokens = append(tokens, qsToken{qsTokIdent, word, col(start)})
+			}
+		default:
+			return nil, fmt.Errorf("at column %d: unexpected character %q", col(i), c)
+		}
+	}
+	tokens = append(tokens, qsToken{qsTokEOF, "", col(i)})
+	return tokens, nil
+}
+
+// lexTypedLiteral consumes the payload following a DATE/TIME keyword,
+// skipping leading whitespace and unquoting the value if needed.
+func lexTypedLiteral(runes []rune, i int) (string, int, int, error) {
+	for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t') {
+		i++
+	}
+	if i >= len(runes) {
+		return "", 0, i, fmt.Errorf("at column %d: expected a date/time literal", i+1)
+	}
+	col := i + 1
+	if runes[i] == '"' || runes[i] == '\'' {
+		quote := runes[i]
+		start := i
+		i++
+		for i < len(runes) && runes[i] != quote {
+			i++
+		}
+		if i >= len(runes) {
+			return "", 0, i, fmt.Errorf("at column %d: unterminated string literal", start+1)
+		}
+		lit := string(runes[start+1 : i])
+		i++
+		return lit, col, i, nil
+	}
+	start := i
+	for i < len(runes) && runes[i] != ' ' && runes[i] != ')' && runes[i] != ',' {
+		i++
+	}
+	return string(runes[start:i]), col, i, nil
+}
+
+func isIdentRune(c rune, first bool) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
+		return true
+	}
+	if !first && (c >= '0' && c <= '9' || c == '.') {
+		return true
+	}
+	return false
+}
+
+// queryStringParser is a recursive-descent parser over a flat token stream.
+// Grammar:
+//
+//	orExpr     := andExpr (OR andExpr)*
+//	andExpr    := term (AND term)*
+//	term       := '(' orExpr ')' | comparison
+//	comparison := IDENT OP value
+//	            | IDENT [NOT] IN '(' value (',' value)* ')'
+//	            | IDENT CONTAINS value
+type queryStringParser struct {
+	tokens []qsToken
+	pos    int
+}
+
+func (p *queryStringParser) peek() qsToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryStringParser) next() qsToken {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryStringParser) expect(kind qsTokKind, what string) (qsToken, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, fmt.Errorf("at column %d: expected %s, got %q", tok.col, what, tok.text)
+	}
+	return tok, nil
+}
+
+func (p *queryStringParser) parseOrExpr() (map[string]interface{}, error) {
+	first, err := p.parseAndExpr()
+	if err != nil {
+		return nil, err
+	}
+	parts := []interface{}{first}
+	for p.peek().kind == qsTokOr {
+		p.next()
+		next, err := p.parseAndExpr()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, next)
+	}
+	if len(parts) == 1 {
+		return first, nil
+	}
+	return map[string]interface{}{"$or": parts}, nil
+}
+
+func (p *queryStringParser) parseAndExpr() (map[string]interface{}, error) {
+	first, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	parts := []interface{}{first}
+	for p.peek().kind == qsTokAnd {
+		p.next()
+		next, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, next)
+	}
+	if len(parts) == 1 {
+		return first, nil
+	}
+	return map[string]interface{}{"$and": parts}, nil
+}
+
+func (p *queryStringParser) parseTerm() (map[string]interface{}, error) {
+	if p.peek().kind == qsTokLParen {
+		p.next()
+		q, err := p.parseOrExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(qsTokRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return q, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryStringParser) parseComparison() (map[string]interface{}, error) {
+	ident, err := p.expect(qsTokIdent, "a field name")
+	if err != nil {
+		return nil, err
+	}
+	negate := false
+	if p.peek().kind == qsTokNot {
+		p.next()
+		negate = true
+	}
+	switch p.peek().kind {
+	case qsTokIn:
+		p.next()
+		if _, err := p.expect(qsTokLParen, "'('"); err != nil {
+			return nil, err
+		}
+		values, err := p.parseValueList()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(qsTokRParen, "')'"); err != nil {
+			return nil, err
+		}
+		op := "$in"
+		if negate {
+			op = "$nin"
+		}
+		return map[string]interface{}{ident.text: map[string]interface{}{op: values}}, nil
+	case qsTokContains:
+		if negate {
+			return nil, fmt.Errorf("at column %d: NOT CONTAINS is not supported", p.peek().col)
+		}
+		p.next()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{ident.text: map[string]interface{}{"$contains": value}}, nil
+	}
+	if negate {
+		return nil, fmt.Errorf("at column %d: expected IN after NOT, got %q", p.peek().col, p.peek().text)
+	}
+	opTok, err := p.expect(qsTokOp, "an operator")
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	switch opTok.text {
+	case "=":
+		return map[string]interface{}{ident.text: value}, nil
+	case "!=":
+		return map[string]interface{}{ident.text: map[string]interface{}{"$ne": value}}, nil
+	case ">":
+		return map[string]interface{}{ident.text: map[string]interface{}{"$gt": value}}, nil
+	case ">=":
+		return map[string]interface{}{ident.text: map[string]interface{}{"$gte": value}}, nil
+	case "<":
+		return map[string]interface{}{ident.text: map[string]interface{}{"$lt": value}}, nil
+	case "<=":
+		return map[string]interface{}{ident.text: map[string]interface{}{"$lte": value}}, nil
+	}
+	return nil, fmt.Errorf("at column %d: unknown operator %q", opTok.col, opTok.text)
+}
+
+func (p *queryStringParser) parseValueList() ([]interface{}, error) {
+	var values []interface{}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	values = append(values, value)
+	for p.peek().kind == qsTokComma {
+		p.next()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (p *queryStringParser) parseValue() (interface{}, error) {
+	tok := p.next()
+	switch tok.kind {
+	case qsTokString:
+		return tok.text, nil
+	case qsTokNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("at column %d: invalid number %q", tok.col, tok.text)
+		}
+		return f, nil
+	case qsTokBool:
+		return strings.ToUpper(tok.text) == "TRUE", nil
+	case qsTokNull:
+		return nil, nil
+	case qsTokDate:
+		t, err := time.Parse("2006-01-02", tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("at column %d: invalid DATE literal %q: %s", tok.col, tok.text, err)
+		}
+		return t, nil
+	case qsTokTime:
+		t, err := time.Parse(time.RFC3339, tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("at column %d: invalid TIME literal %q: %s", tok.col, tok.text, err)
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("at column %d: expected value, got %q", tok.col, tok.text)
+}
@@ -0,0 +1,156 @@
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rs/rest-layer/schema"
+)
+
+// arrayOfStringsValidator stands in for a slice/array field validator (not
+// present in this tree): it validates the whole slice and rejects a lone
+// scalar element, the way a real Array validator would.
+type arrayOfStringsValidator struct{}
+
+func (arrayOfStringsValidator) Validate(value interface{}) (interface{}, error) {
+	if _, ok := value.([]interface{}); !ok {
+		return nil, fmt.Errorf("must be an array")
+	}
+	return value, nil
+}
+
+func newQueryStringTestValidator() schema.Validator {
+	return &schema.Schema{
+		Fields: schema.Fields{
+			"status": schema.Field{
+				Filterable: true,
+				Validator:  &schema.String{},
+			},
+			"tx": schema.Field{
+				Filterable: true,
+				Schema: &schema.Schema{
+					Fields: schema.Fields{
+						"gas": schema.Field{
+							Filterable: true,
+							Validator:  &schema.Integer{},
+						},
+					},
+				},
+			},
+			"tags": schema.Field{
+				Filterable: true,
+				Validator:  &schema.String{},
+			},
+			"created_at": schema.Field{
+				Filterable: true,
+				Validator:  &schema.Time{},
+			},
+			"labels": schema.Field{
+				Filterable: true,
+				Validator:  arrayOfStringsValidator{},
+			},
+		},
+	}
+}
+
+func TestParseQueryStringEmpty(t *testing.T) {
+	q, err := schema.ParseQueryString("", newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(q) != 0 {
+		t.Errorf("expected an empty query, got %v", q)
+	}
+}
+
+func TestParseQueryStringSimple(t *testing.T) {
+	q, err := schema.ParseQueryString(`status = "active"`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if q["status"] != "active" {
+		t.Errorf("expected status=active, got %v", q["status"])
+	}
+}
+
+func TestParseQueryStringPrecedence(t *testing.T) {
+	// AND should bind tighter than OR: `a OR b AND c` == `a OR (b AND c)`.
+	q, err := schema.ParseQueryString(`status = "a" OR status = "b" AND status = "c"`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// validateQuery rewrites $and/$or's value to []schema.Query before
+	// ParseQueryString returns, not the []interface{} it was built with.
+	or, ok := q["$or"].([]schema.Query)
+	if !ok || len(or) != 2 {
+		t.Fatalf("expected a 2-element $or, got %v", q)
+	}
+	if _, ok := or[1]["$and"]; !ok {
+		t.Errorf("expected second $or branch to be an $and, got %v", or[1])
+	}
+}
+
+func TestParseQueryStringIn(t *testing.T) {
+	q, err := schema.ParseQueryString(`tags IN ("a", "b")`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sub, ok := q["tags"].(schema.Query)
+	if !ok {
+		t.Fatalf("expected tags to hold a sub-query, got %T", q["tags"])
+	}
+	values, ok := sub["$in"].([]interface{})
+	if !ok || len(values) != 2 {
+		t.Errorf("expected two values in $in, got %v", sub["$in"])
+	}
+}
+
+func TestParseQueryStringUnbalancedParens(t *testing.T) {
+	if _, err := schema.ParseQueryString(`(status = "a"`, newQueryStringTestValidator()); err == nil {
+		t.Error("expected an error for unbalanced parens")
+	}
+}
+
+func TestParseQueryStringUnknownField(t *testing.T) {
+	if _, err := schema.ParseQueryString(`nope = "a"`, newQueryStringTestValidator()); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestParseQueryStringTypeMismatch(t *testing.T) {
+	_, err := schema.ParseQueryString(`tx.gas > "not a number"`, newQueryStringTestValidator())
+	if err == nil {
+		t.Fatal("expected an error for a type mismatch between the literal and the field validator")
+	}
+	// Pin the exact rejection so this doesn't pass incidentally via the
+	// unrelated "non numerical field" branch (which a *Integer validator
+	// used to fall into before the pointer-type switch bug was fixed).
+	if want := "tx.gas: value for $gt must be a number"; err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err.Error())
+	}
+}
+
+// BenchmarkParseQueryString and BenchmarkParseQuery compare the dedicated
+// scanner against json.Unmarshal plus reflective validation on an equivalent
+// expression.
+func BenchmarkParseQueryString(b *testing.B) {
+	v := newQueryStringTestValidator()
+	expr := `status = "active" AND tx.gas > 7`
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := schema.ParseQueryString(expr, v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseQuery(b *testing.B) {
+	v := newQueryStringTestValidator()
+	expr := `{"status": "active", "tx.gas": {"$gt": 7}}`
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := schema.ParseQuery(expr, v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package schema_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/rs/rest-layer/schema"
+)
+
+func TestQueryMatchRegex(t *testing.T) {
+	q := schema.Query{"name": schema.Query{"$regex": regexp.MustCompile("^foo")}}
+	if !q.Match(map[string]interface{}{"name": "foobar"}) {
+		t.Error("expected a match on a $regex prefix")
+	}
+	if q.Match(map[string]interface{}{"name": "barfoo"}) {
+		t.Error("expected no match on a $regex prefix")
+	}
+}
+
+func TestParseQueryRegexOnPointerStringField(t *testing.T) {
+	// status is declared with a *String (pointer) validator; $regex must not
+	// be rejected as "non string field" because of that.
+	q, err := schema.ParseQuery(`{"status": {"$regex": "^act"}}`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !q.Match(map[string]interface{}{"status": "active"}) {
+		t.Error("expected the compiled $regex to match")
+	}
+}
+
+func TestParseQueryContainsOnPointerStringField(t *testing.T) {
+	// Same pointer-type concern for $contains's String-substring validation
+	// path.
+	q, err := schema.ParseQuery(`{"status": {"$contains": "ctiv"}}`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !q.Match(map[string]interface{}{"status": "active"}) {
+		t.Error("expected $contains to match a substring")
+	}
+}
+
+func TestQueryMatchExists(t *testing.T) {
+	existsTrue := schema.Query{"name": schema.Query{"$exists": true}}
+	if !existsTrue.Match(map[string]interface{}{"name": "foo"}) {
+		t.Error("expected $exists:true to match a present field")
+	}
+	if existsTrue.Match(map[string]interface{}{}) {
+		t.Error("expected $exists:true not to match an absent field")
+	}
+
+	existsFalse := schema.Query{"name": schema.Query{"$exists": false}}
+	if !existsFalse.Match(map[string]interface{}{}) {
+		t.Error("expected $exists:false to match an absent field")
+	}
+	if existsFalse.Match(map[string]interface{}{"name": "foo"}) {
+		t.Error("expected $exists:false not to match a present field")
+	}
+}
+
+func TestQueryMatchContains(t *testing.T) {
+	strQ := schema.Query{"name": schema.Query{"$contains": "oob"}}
+	if !strQ.Match(map[string]interface{}{"name": "foobar"}) {
+		t.Error("expected $contains to match a substring")
+	}
+	if strQ.Match(map[string]interface{}{"name": "barbaz"}) {
+		t.Error("expected $contains not to match")
+	}
+
+	t.Run("element-membership query against a real array validator", func(t *testing.T) {
+		// labels is backed by a validator that expects a whole []interface{}
+		// and would reject the single "x" element if asked to validate it
+		// directly; $contains must not run it against a lone element.
+		if _, err := schema.ParseQueryString(`labels CONTAINS "x"`, newQueryStringTestValidator()); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	sliceQ := schema.Query{"tags": schema.Query{"$contains": "a"}}
+	if !sliceQ.Match(map[string]interface{}{"tags": []interface{}{"a", "b"}}) {
+		t.Error("expected $contains to match a slice element")
+	}
+	if sliceQ.Match(map[string]interface{}{"tags": []interface{}{"b", "c"}}) {
+		t.Error("expected $contains not to match a slice missing the element")
+	}
+}
+
+func TestQueryMatchTimeComparison(t *testing.T) {
+	ref, _ := time.Parse(time.RFC3339, "2018-11-18T17:15:16Z")
+	before, _ := time.Parse(time.RFC3339, "2018-11-17T00:00:00Z")
+	after, _ := time.Parse(time.RFC3339, "2018-11-19T00:00:00Z")
+
+	q := schema.Query{"created_at": schema.Query{"$gt": ref}}
+	if q.Match(map[string]interface{}{"created_at": before}) {
+		t.Error("expected $gt not to match an earlier time")
+	}
+	if !q.Match(map[string]interface{}{"created_at": after}) {
+		t.Error("expected $gt to match a later time")
+	}
+}
+
+func TestResolveTypedLiteralInQuery(t *testing.T) {
+	q, err := schema.ParseQuery(`{"created_at": {"$gt": "DATE 2018-11-18"}}`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sub, ok := q["created_at"].(schema.Query)
+	if !ok {
+		t.Fatalf("expected created_at to hold a sub-query, got %T", q["created_at"])
+	}
+	if _, ok := sub["$gt"].(time.Time); !ok {
+		t.Errorf("expected the DATE literal to resolve to a time.Time, got %T", sub["$gt"])
+	}
+}
+
+func TestParseQueryTimeFieldPlainRFC3339String(t *testing.T) {
+	// The motivating case: an RFC3339 string against a Time field, with no
+	// DATE/TIME literal prefix, must still end up comparable as a time.Time.
+	q, err := schema.ParseQuery(`{"created_at": {"$gt": "2018-11-18T17:15:16Z"}}`, newQueryStringTestValidator())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sub, ok := q["created_at"].(schema.Query)
+	if !ok {
+		t.Fatalf("expected created_at to hold a sub-query, got %T", q["created_at"])
+	}
+	if _, ok := sub["$gt"].(time.Time); !ok {
+		t.Fatalf("expected the validated value to be a time.Time, got %T", sub["$gt"])
+	}
+
+	after, _ := time.Parse(time.RFC3339, "2018-11-19T00:00:00Z")
+	before, _ := time.Parse(time.RFC3339, "2018-11-17T00:00:00Z")
+	if !q.Match(map[string]interface{}{"created_at": after}) {
+		t.Error("expected $gt to match a later time.Time payload")
+	}
+	if q.Match(map[string]interface{}{"created_at": before}) {
+		t.Error("expected $gt not to match an earlier time.Time payload")
+	}
+}
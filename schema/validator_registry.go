@@ -0,0 +1,163 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// ValidatorFactory builds a FieldValidator from the parameters found in a
+// JSON/YAML schema definition (e.g. {"type": "string", "pattern": "^[a-z]+$"}
+// yields params = {"pattern": "^[a-z]+$"}).
+type ValidatorFactory func(params map[string]interface{}) (FieldValidator, error)
+
+var (
+	validatorRegistryMu sync.RWMutex
+	validatorRegistry   = map[string]ValidatorFactory{}
+)
+
+// RegisterValidator registers a FieldValidator factory under name so schemas
+// loaded from a config format (Field.UnmarshalJSON) can reference it by its
+// "type". Registering under an already used name replaces the previous
+// factory.
+func RegisterValidator(name string, factory ValidatorFactory) {
+	validatorRegistryMu.Lock()
+	defer validatorRegistryMu.Unlock()
+	validatorRegistry[name] = factory
+}
+
+// newRegisteredValidator looks up name in the registry and builds a
+// validator from params. It is used by Field.UnmarshalJSON to resolve the
+// "type" key of a field definition.
+func newRegisteredValidator(name string, params map[string]interface{}) (FieldValidator, error) {
+	validatorRegistryMu.RLock()
+	factory, ok := validatorRegistry[name]
+	validatorRegistryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown validator type: %s", name)
+	}
+	return factory(params)
+}
+
+func init() {
+	RegisterValidator("string", func(params map[string]interface{}) (FieldValidator, error) {
+		var v FieldValidator = &String{}
+		if expr, ok := params["pattern"].(string); ok && expr != "" {
+			p := &Pattern{Expr: expr}
+			if err := p.Compile(nil); err != nil {
+				return nil, err
+			}
+			v = p
+		}
+		if values, ok := params["enum"].([]interface{}); ok {
+			v = &Enum{Values: values, Base: v}
+		}
+		return v, nil
+	})
+	RegisterValidator("integer", func(params map[string]interface{}) (FieldValidator, error) {
+		return &Integer{}, nil
+	})
+	RegisterValidator("float", func(params map[string]interface{}) (FieldValidator, error) {
+		return &Float{}, nil
+	})
+	RegisterValidator("bool", func(params map[string]interface{}) (FieldValidator, error) {
+		return &Bool{}, nil
+	})
+	RegisterValidator("pattern", func(params map[string]interface{}) (FieldValidator, error) {
+		expr, _ := params["pattern"].(string)
+		p := &Pattern{Expr: expr}
+		if err := p.Compile(nil); err != nil {
+			return nil, err
+		}
+		return p, nil
+	})
+	RegisterValidator("enum", func(params map[string]interface{}) (FieldValidator, error) {
+		values, _ := params["enum"].([]interface{})
+		enum := &Enum{Values: values}
+		if base, ok := params["base"].(string); ok && base != "" {
+			baseParams, _ := params["baseParams"].(map[string]interface{})
+			baseValidator, err := newRegisteredValidator(base, baseParams)
+			if err != nil {
+				return nil, fmt.Errorf("enum: invalid base: %s", err)
+			}
+			enum.Base = baseValidator
+		}
+		return enum, nil
+	})
+}
+
+// Pattern validates that a string value matches a regular expression.
+type Pattern struct {
+	// Expr is the regular expression the value must match, as provided in
+	// the schema definition.
+	Expr string
+	// Regexp holds the compiled pattern. It is populated by Compile and
+	// should not be set directly.
+	Regexp *regexp.Regexp
+}
+
+// Compile compiles Expr into Regexp.
+func (v *Pattern) Compile(rc ReferenceChecker) error {
+	re, err := regexp.Compile(v.Expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %s", err)
+	}
+	v.Regexp = re
+	return nil
+}
+
+// Validate validates and normalize the pattern value.
+func (v Pattern) Validate(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("not a string")
+	}
+	if v.Regexp == nil {
+		return nil, fmt.Errorf("field compiled without a valid pattern")
+	}
+	if !v.Regexp.MatchString(str) {
+		return nil, fmt.Errorf("must match %s", v.Expr)
+	}
+	return str, nil
+}
+
+// Enum validates that a value, once coerced by Base, is one of Values. It
+// lets config-driven schemas compose a type (Base) with a set of allowed
+// values instead of baking enums into the core types.
+type Enum struct {
+	// Values is the list of allowed values, compared with the coerced
+	// value using reflect.DeepEqual.
+	Values []interface{}
+	// Base is the validator used to coerce the value before the enum
+	// membership check (e.g. schema.String{}). May be nil, in which case
+	// the raw value is compared as-is.
+	Base FieldValidator
+}
+
+// Compile compiles Base if it implements a compiler.
+func (v *Enum) Compile(rc ReferenceChecker) error {
+	if compiler, ok := v.Base.(interface {
+		Compile(ReferenceChecker) error
+	}); ok {
+		return compiler.Compile(rc)
+	}
+	return nil
+}
+
+// Validate validates and normalize the enum value.
+func (v Enum) Validate(value interface{}) (interface{}, error) {
+	if v.Base != nil {
+		var err error
+		value, err = v.Base.Validate(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, allowed := range v.Values {
+		if reflect.DeepEqual(allowed, value) {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("not one of the allowed values: %v", v.Values)
+}
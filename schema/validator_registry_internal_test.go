@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type alwaysValidValidator struct{}
+
+func (alwaysValidValidator) Validate(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("test-always-valid", func(params map[string]interface{}) (FieldValidator, error) {
+		return alwaysValidValidator{}, nil
+	})
+	v, err := newRegisteredValidator("test-always-valid", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := v.Validate("anything"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := newRegisteredValidator("does-not-exist", nil); err == nil {
+		t.Error("expected an error for an unregistered validator name")
+	}
+}
+
+func TestFieldUnmarshalJSONPattern(t *testing.T) {
+	var f Field
+	if err := json.Unmarshal([]byte(`{"type":"pattern","pattern":"^[a-z]+$","filterable":true}`), &f); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !f.Filterable {
+		t.Error("expected filterable to be true")
+	}
+	if _, err := f.Validator.Validate("abc"); err != nil {
+		t.Errorf("expected \"abc\" to validate: %s", err)
+	}
+	if _, err := f.Validator.Validate("ABC"); err == nil {
+		t.Error("expected \"ABC\" to be rejected")
+	}
+}
+
+func TestFieldUnmarshalJSONEnumWithBase(t *testing.T) {
+	var f Field
+	if err := json.Unmarshal([]byte(`{"type":"enum","base":"string","enum":["a","b"]}`), &f); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	enum, ok := f.Validator.(*Enum)
+	if !ok {
+		t.Fatalf("expected a *Enum validator, got %T", f.Validator)
+	}
+	if enum.Base == nil {
+		t.Fatal("expected the enum's Base to be resolved from \"base\":\"string\"")
+	}
+	if _, err := f.Validator.Validate("a"); err != nil {
+		t.Errorf("expected \"a\" to validate: %s", err)
+	}
+	if _, err := f.Validator.Validate("c"); err == nil {
+		t.Error("expected \"c\" to be rejected as not in the enum")
+	}
+}
@@ -0,0 +1,35 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/rs/rest-layer/schema"
+)
+
+func TestPatternValidate(t *testing.T) {
+	v := schema.Pattern{Expr: "^[a-z]+$"}
+	if err := v.Compile(nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := v.Validate("abc"); err != nil {
+		t.Errorf("expected \"abc\" to match the pattern: %s", err)
+	}
+	if _, err := v.Validate("ABC"); err == nil {
+		t.Error("expected \"ABC\" not to match the pattern")
+	}
+}
+
+func TestEnumValidate(t *testing.T) {
+	v := schema.Enum{Values: []interface{}{"a", "b"}, Base: schema.String{}}
+	value, err := v.Validate("a")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if value != "a" {
+		t.Errorf("expected value to be \"a\", got %v", value)
+	}
+	if _, err := v.Validate("c"); err == nil {
+		t.Error("expected \"c\" to be rejected as not in the enum")
+	}
+}
+